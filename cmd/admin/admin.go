@@ -0,0 +1,53 @@
+// Package admin implements the `passwall admin` CLI subcommand: out-of-band user and login
+// management that reuses the same storage.Store, config and app packages as the HTTP server.
+package admin
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/passwall/passwall-server/internal/config"
+	"github.com/passwall/passwall-server/internal/storage"
+	"github.com/passwall/passwall-server/internal/storage/postgres"
+	"github.com/spf13/cobra"
+)
+
+// Cmd is the root `admin` command, registered by the main passwall CLI entrypoint
+var Cmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Manage users and logins without going through the HTTP API",
+}
+
+func init() {
+	Cmd.AddCommand(addUserCmd)
+	Cmd.AddCommand(resetPassCmd)
+	Cmd.AddCommand(listUsersCmd)
+	Cmd.AddCommand(rotatePassphraseCmd)
+	Cmd.AddCommand(importCmd)
+	Cmd.AddCommand(exportCmd)
+}
+
+// newStore loads the server configuration and opens a storage.Store the same way the HTTP
+// server does, so admin commands always operate on the exact same database.
+func newStore() storage.Store {
+	cfg, err := config.Init(configDir(), "passwall")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to load configuration:", err)
+		os.Exit(1)
+	}
+
+	store, err := postgres.NewStore(cfg.Database)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to connect to database:", err)
+		os.Exit(1)
+	}
+
+	return store
+}
+
+func configDir() string {
+	if dir := os.Getenv("PW_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	return "/app/config"
+}
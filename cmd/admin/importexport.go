@@ -0,0 +1,158 @@
+package admin
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/passwall/passwall-server/internal/app"
+	"github.com/passwall/passwall-server/model"
+	"github.com/spf13/cobra"
+)
+
+const loginsFileMode = 0600
+
+var (
+	importFile string
+	exportFile string
+	schemaName string
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import logins from a csv or json file",
+	Run: func(cmd *cobra.Command, args []string) {
+		store := newStore()
+
+		loginDTOs, err := readLoginsFile(importFile)
+		if err != nil {
+			fmt.Println("error:", err)
+			return
+		}
+
+		imported := 0
+		for _, dto := range loginDTOs {
+			dto := dto
+			if _, err := app.CreateLogin(store, &dto, schemaName); err != nil {
+				fmt.Printf("skipping %q: %v\n", dto.Title, err)
+				continue
+			}
+			imported++
+		}
+
+		fmt.Printf("imported %d/%d logins\n", imported, len(loginDTOs))
+	},
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export logins to a csv or json file",
+	Run: func(cmd *cobra.Command, args []string) {
+		store := newStore()
+
+		logins, err := store.Logins().All(schemaName)
+		if err != nil {
+			fmt.Println("error:", err)
+			return
+		}
+
+		decrypted := make([]model.Login, 0, len(logins))
+		for i := range logins {
+			decLogin, err := app.DecryptModel(&logins[i])
+			if err != nil {
+				fmt.Println("error decrypting login:", err)
+				return
+			}
+			decrypted = append(decrypted, *decLogin.(*model.Login))
+		}
+
+		if err := writeLoginsFile(exportFile, decrypted); err != nil {
+			fmt.Println("error:", err)
+			return
+		}
+
+		fmt.Printf("exported %d logins to %s\n", len(decrypted), exportFile)
+	},
+}
+
+func readLoginsFile(path string) ([]model.LoginDTO, error) {
+	if strings.HasSuffix(path, ".json") {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var loginDTOs []model.LoginDTO
+		if err := json.Unmarshal(data, &loginDTOs); err != nil {
+			return nil, err
+		}
+		return loginDTOs, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	var loginDTOs []model.LoginDTO
+	for i, record := range records[1:] { // skip header row
+		if len(record) != 4 {
+			return nil, fmt.Errorf("row %d: expected 4 columns (title, username, password, url), got %d", i+2, len(record))
+		}
+		loginDTOs = append(loginDTOs, model.LoginDTO{
+			Title:    record[0],
+			Username: record[1],
+			Password: record[2],
+			URL:      record[3],
+		})
+	}
+
+	return loginDTOs, nil
+}
+
+func writeLoginsFile(path string, logins []model.Login) error {
+	if strings.HasSuffix(path, ".json") {
+		data, err := json.MarshalIndent(logins, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, data, loginsFileMode)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, loginsFileMode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	writer.Write([]string{"title", "username", "password", "url"})
+	for _, login := range logins {
+		writer.Write([]string{login.Title, login.Username, login.Password, login.URL})
+	}
+
+	return nil
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFile, "file", "", "path to the csv or json file to import")
+	importCmd.Flags().StringVar(&schemaName, "schema", "public", "tenant schema to import into")
+	importCmd.MarkFlagRequired("file")
+
+	exportCmd.Flags().StringVar(&exportFile, "file", "", "path to write the csv or json export to")
+	exportCmd.Flags().StringVar(&schemaName, "schema", "public", "tenant schema to export from")
+	exportCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/passwall/passwall-server/model"
+)
+
+func TestReadLoginsFileAcceptsEmptyCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logins.csv")
+	if err := os.WriteFile(path, []byte{}, 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	dtos, err := readLoginsFile(path)
+	if err != nil {
+		t.Fatalf("expected an empty file to be handled cleanly, got error: %v", err)
+	}
+	if len(dtos) != 0 {
+		t.Fatalf("expected no logins from an empty file, got %d", len(dtos))
+	}
+}
+
+func TestReadLoginsFileRejectsShortRow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logins.csv")
+	content := "title,username,password,url\nonly,two\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	if _, err := readLoginsFile(path); err == nil {
+		t.Fatal("expected a row with fewer than 4 columns to return an error, not panic or silently succeed")
+	}
+}
+
+func TestReadLoginsFileParsesWellFormedCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logins.csv")
+	content := "title,username,password,url\nGitHub,me,hunter2,https://github.com\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	dtos, err := readLoginsFile(path)
+	if err != nil {
+		t.Fatalf("readLoginsFile: %v", err)
+	}
+	if len(dtos) != 1 || dtos[0].Title != "GitHub" || dtos[0].URL != "https://github.com" {
+		t.Fatalf("unexpected parse result: %+v", dtos)
+	}
+}
+
+func TestWriteLoginsFileUsesRestrictivePermissions(t *testing.T) {
+	logins := []model.Login{{Title: "GitHub", Username: "me", Password: "hunter2", URL: "https://github.com"}}
+
+	csvPath := filepath.Join(t.TempDir(), "out.csv")
+	if err := writeLoginsFile(csvPath, logins); err != nil {
+		t.Fatalf("writeLoginsFile (csv): %v", err)
+	}
+	assertFileMode(t, csvPath, 0600)
+
+	jsonPath := filepath.Join(t.TempDir(), "out.json")
+	if err := writeLoginsFile(jsonPath, logins); err != nil {
+		t.Fatalf("writeLoginsFile (json): %v", err)
+	}
+	assertFileMode(t, jsonPath, 0600)
+}
+
+func assertFileMode(t *testing.T, path string, want os.FileMode) {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat %s: %v", path, err)
+	}
+	if got := info.Mode().Perm(); got != want {
+		t.Errorf("%s has mode %v, want %v", path, got, want)
+	}
+}
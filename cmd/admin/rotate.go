@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/passwall/passwall-server/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	oldPassphrase string
+	newPassphrase string
+)
+
+// rotatePassphraseCmd re-encrypts every stored secret across every tenant schema, decrypting
+// with oldPassphrase and encrypting with newPassphrase. Each schema is rotated independently:
+// if a schema fails partway through, it is skipped and reported so already-rotated schemas are
+// not retried.
+var rotatePassphraseCmd = &cobra.Command{
+	Use:   "rotate-passphrase",
+	Short: "Re-encrypt every stored secret with a new server passphrase",
+	Run: func(cmd *cobra.Command, args []string) {
+		store := newStore()
+
+		schemas, err := store.Users().AllSchemas()
+		if err != nil {
+			fmt.Println("error listing schemas:", err)
+			return
+		}
+
+		for _, schema := range schemas {
+			if err := app.RotatePassphrase(store, oldPassphrase, newPassphrase, schema); err != nil {
+				fmt.Printf("schema %s: rotation failed, rolled back: %v\n", schema, err)
+				continue
+			}
+			fmt.Printf("schema %s: rotation complete\n", schema)
+		}
+	},
+}
+
+func init() {
+	rotatePassphraseCmd.Flags().StringVar(&oldPassphrase, "old", "", "current server passphrase")
+	rotatePassphraseCmd.Flags().StringVar(&newPassphrase, "new", "", "new server passphrase")
+	rotatePassphraseCmd.MarkFlagRequired("old")
+	rotatePassphraseCmd.MarkFlagRequired("new")
+}
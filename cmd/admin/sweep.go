@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// sweepExpiredResetsCmd deletes expired/used password reset tokens from every tenant schema, so
+// the password_resets table does not grow unbounded. Intended to be run periodically (e.g. from
+// cron) alongside the HTTP server.
+var sweepExpiredResetsCmd = &cobra.Command{
+	Use:   "sweep-expired-resets",
+	Short: "Delete expired password reset tokens across every tenant schema",
+	Run: func(cmd *cobra.Command, args []string) {
+		store := newStore()
+
+		schemas, err := store.Users().AllSchemas()
+		if err != nil {
+			fmt.Println("error listing schemas:", err)
+			return
+		}
+
+		for _, schema := range schemas {
+			if err := store.PasswordResets().DeleteExpired(schema); err != nil {
+				fmt.Printf("schema %s: sweep failed: %v\n", schema, err)
+				continue
+			}
+			fmt.Printf("schema %s: expired reset tokens swept\n", schema)
+		}
+	},
+}
+
+func init() {
+	Cmd.AddCommand(sweepExpiredResetsCmd)
+}
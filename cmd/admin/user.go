@@ -0,0 +1,85 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/passwall/passwall-server/internal/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	userEmail    string
+	userPassword string
+	userIsAdmin  bool
+)
+
+var addUserCmd = &cobra.Command{
+	Use:   "adduser",
+	Short: "Create a new user",
+	Run: func(cmd *cobra.Command, args []string) {
+		store := newStore()
+
+		createdUser, err := app.CreateUser(store, userEmail, userPassword, "public")
+		if err != nil {
+			fmt.Println("Error:", err)
+			return
+		}
+
+		if userIsAdmin {
+			createdUser.IsAdmin = true
+			if createdUser, err = store.Users().Update(createdUser, "public"); err != nil {
+				fmt.Println("Error:", err)
+				return
+			}
+		}
+
+		fmt.Printf("user %s (id=%d) created successfully\n", createdUser.Email, createdUser.ID)
+		if !createdUser.Verified {
+			fmt.Println("a verification email has been sent; the user must confirm it before logging in")
+		}
+	},
+}
+
+var resetPassCmd = &cobra.Command{
+	Use:   "resetpass",
+	Short: "Send a password reset email for a user",
+	Run: func(cmd *cobra.Command, args []string) {
+		store := newStore()
+
+		if err := app.RequestPasswordReset(store, userEmail, "public"); err != nil {
+			fmt.Println("Error:", err)
+			return
+		}
+
+		fmt.Println("password reset email sent, if the account exists")
+	},
+}
+
+var listUsersCmd = &cobra.Command{
+	Use:   "listusers",
+	Short: "List all users",
+	Run: func(cmd *cobra.Command, args []string) {
+		store := newStore()
+
+		users, err := store.Users().All("public")
+		if err != nil {
+			fmt.Println("Error:", err)
+			return
+		}
+
+		for _, user := range users {
+			fmt.Printf("%d\t%s\tadmin=%t\n", user.ID, user.Email, user.IsAdmin)
+		}
+	},
+}
+
+func init() {
+	addUserCmd.Flags().StringVar(&userEmail, "email", "", "email of the new user")
+	addUserCmd.Flags().StringVar(&userPassword, "password", "", "master password of the new user")
+	addUserCmd.Flags().BoolVar(&userIsAdmin, "admin", false, "grant admin privileges")
+	addUserCmd.MarkFlagRequired("email")
+	addUserCmd.MarkFlagRequired("password")
+
+	resetPassCmd.Flags().StringVar(&userEmail, "email", "", "email of the user to reset")
+	resetPassCmd.MarkFlagRequired("email")
+}
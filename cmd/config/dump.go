@@ -0,0 +1,46 @@
+// Package config implements the `passwall config` CLI subcommand.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/passwall/passwall-server/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// Cmd is the root `config` command, registered by the main passwall CLI entrypoint
+var Cmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the server configuration",
+}
+
+var dumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Print every configuration key the server honors and its current value",
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := config.Init(configDir(), "passwall"); err != nil {
+			fmt.Fprintln(os.Stderr, "failed to load configuration:", err)
+			os.Exit(1)
+		}
+
+		for _, key := range config.All() {
+			value := key.GetString()
+			if key.Sensitive() {
+				value = "<redacted>"
+			}
+			fmt.Printf("%-45s %v\n", key.String(), value)
+		}
+	},
+}
+
+func init() {
+	Cmd.AddCommand(dumpCmd)
+}
+
+func configDir() string {
+	if dir := os.Getenv("PW_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	return "/app/config"
+}
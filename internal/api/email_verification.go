@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/app"
+	"github.com/passwall/passwall-server/internal/storage"
+	"github.com/passwall/passwall-server/model"
+)
+
+// resendRateLimitWindow is how long a requesting IP must wait before it can trigger another
+// resend, for any account.
+const resendRateLimitWindow = time.Minute
+
+var resendLimiter = newRequestRateLimiter(1, resendRateLimitWindow)
+
+// VerifyEmailPayload is the request body of VerifyEmail
+type VerifyEmailPayload struct {
+	Token string `json:"token"`
+}
+
+// ResendVerificationPayload is the request body of ResendVerification
+type ResendVerificationPayload struct {
+	Email string `json:"email"`
+}
+
+// VerifyEmail confirms a user's email address from a verification token
+func VerifyEmail(s storage.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var payload VerifyEmailPayload
+
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&payload); err != nil {
+			RespondWithError(w, http.StatusBadRequest, InvalidRequestPayload)
+			return
+		}
+		defer r.Body.Close()
+
+		schema := r.Context().Value("schema").(string)
+		if err := app.VerifyEmail(s, payload.Token, schema); err != nil {
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		response := model.Response{
+			Code:    http.StatusOK,
+			Status:  Success,
+			Message: "Email verified successfully!",
+		}
+		RespondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+// ResendVerification resends the email verification link for an account, rate limited per
+// requesting IP to one request per resendRateLimitWindow.
+func ResendVerification(s storage.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var payload ResendVerificationPayload
+
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&payload); err != nil {
+			RespondWithError(w, http.StatusBadRequest, InvalidRequestPayload)
+			return
+		}
+		defer r.Body.Close()
+
+		if !resendLimiter.Allow(clientIP(r)) {
+			RespondWithError(w, http.StatusTooManyRequests, "Too many requests, please try again later")
+			return
+		}
+
+		schema := r.Context().Value("schema").(string)
+		if err := app.ResendVerificationEmail(s, payload.Email, schema); err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		response := model.Response{
+			Code:    http.StatusOK,
+			Status:  Success,
+			Message: "If that email is registered and unverified, a new verification link has been sent!",
+		}
+		RespondWithJSON(w, http.StatusOK, response)
+	}
+}
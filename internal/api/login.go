@@ -6,9 +6,9 @@ import (
 	"strconv"
 
 	"github.com/passwall/passwall-server/internal/app"
+	"github.com/passwall/passwall-server/internal/config"
 	"github.com/passwall/passwall-server/internal/storage"
 	"github.com/passwall/passwall-server/model"
-	"github.com/spf13/viper"
 
 	"github.com/gorilla/mux"
 )
@@ -90,7 +90,7 @@ func CreateLogin(s storage.Store) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
 		// Setup variables
-		env := viper.GetString("server.env")
+		env := config.ServerEnv.GetString()
 		transmissionKey := r.Context().Value("transmissionKey").(string)
 
 		// Update request body according to env.
@@ -144,7 +144,7 @@ func UpdateLogin(s storage.Store) http.HandlerFunc {
 		}
 
 		// Setup variables
-		env := viper.GetString("server.env")
+		env := config.ServerEnv.GetString()
 		transmissionKey := r.Context().Value("transmissionKey").(string)
 
 		if err := ToBody(r, env, transmissionKey); err != nil {
@@ -171,7 +171,7 @@ func UpdateLogin(s storage.Store) http.HandlerFunc {
 		}
 
 		// Update login
-		updatedLogin, err := app.UpdateLogin(s, login, &loginDTO, schema)
+		updatedLogin, err := app.UpdateLogin(s, login, &loginDTO, schema, nil)
 		if err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err.Error())
 			return
@@ -191,14 +191,46 @@ func UpdateLogin(s storage.Store) http.HandlerFunc {
 	}
 }
 
-// BulkUpdateLogins updates logins in payload
+// BulkCreateLogins creates every login in payload inside a single transaction, reporting which
+// ids were created and which failed instead of aborting at the first error
+func BulkCreateLogins(s storage.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var loginList []model.LoginDTO
+
+		env := config.ServerEnv.GetString()
+		transmissionKey := r.Context().Value("transmissionKey").(string)
+		if err := ToBody(r, env, transmissionKey); err != nil {
+			RespondWithError(w, http.StatusBadRequest, InvalidRequestPayload)
+			return
+		}
+		defer r.Body.Close()
+
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&loginList); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Invalid resquest payload")
+			return
+		}
+		defer r.Body.Close()
+
+		schema := r.Context().Value("schema").(string)
+		result, err := app.BulkCreateLogins(s, loginList, schema)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		RespondWithEncJSON(w, http.StatusOK, transmissionKey, result)
+	}
+}
+
+// BulkUpdateLogins updates every login in payload inside a single transaction, reporting which
+// ids were updated and which failed instead of aborting at the first error
 func BulkUpdateLogins(s storage.Store) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var loginList []model.LoginDTO
-		// var loginDTO model.LoginDTO
 
 		// Setup variables
-		env := viper.GetString("server.env")
+		env := config.ServerEnv.GetString()
 		transmissionKey := r.Context().Value("transmissionKey").(string)
 		if err := ToBody(r, env, transmissionKey); err != nil {
 			RespondWithError(w, http.StatusBadRequest, InvalidRequestPayload)
@@ -208,33 +240,51 @@ func BulkUpdateLogins(s storage.Store) http.HandlerFunc {
 
 		decoder := json.NewDecoder(r.Body)
 		if err := decoder.Decode(&loginList); err != nil {
-			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			RespondWithError(w, http.StatusBadRequest, "Invalid resquest payload")
+			return
 		}
 		defer r.Body.Close()
 
-		for _, loginDTO := range loginList {
-			// Find login defined by id
-			schema := r.Context().Value("schema").(string)
-			login, err := s.Logins().FindByID(loginDTO.ID, schema)
-			if err != nil {
-				RespondWithError(w, http.StatusNotFound, err.Error())
-				return
-			}
+		schema := r.Context().Value("schema").(string)
+		result, err := app.BulkUpdateLogins(s, loginList, schema)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
 
-			// Update login
-			_, err = app.UpdateLogin(s, login, &loginDTO, schema)
-			if err != nil {
-				RespondWithError(w, http.StatusInternalServerError, err.Error())
-				return
-			}
+		RespondWithEncJSON(w, http.StatusOK, transmissionKey, result)
+	}
+}
+
+// BulkDeleteLogins deletes every login id in payload inside a single transaction, reporting
+// which ids were deleted and which failed instead of aborting at the first error
+func BulkDeleteLogins(s storage.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var ids []uint
+
+		env := config.ServerEnv.GetString()
+		transmissionKey := r.Context().Value("transmissionKey").(string)
+		if err := ToBody(r, env, transmissionKey); err != nil {
+			RespondWithError(w, http.StatusBadRequest, InvalidRequestPayload)
+			return
 		}
+		defer r.Body.Close()
 
-		response := model.Response{
-			Code:    http.StatusOK,
-			Status:  "Success",
-			Message: "Bulk update completed successfully!",
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&ids); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Invalid resquest payload")
+			return
 		}
-		RespondWithJSON(w, http.StatusOK, response)
+		defer r.Body.Close()
+
+		schema := r.Context().Value("schema").(string)
+		result, err := app.BulkDeleteLogins(s, ids, schema)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		RespondWithEncJSON(w, http.StatusOK, transmissionKey, result)
 	}
 }
 
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/app"
+	"github.com/passwall/passwall-server/internal/config"
+	"github.com/passwall/passwall-server/internal/storage"
+	"github.com/passwall/passwall-server/model"
+)
+
+var (
+	passwordResetEmailLimiter *requestRateLimiter
+	passwordResetIPLimiter    *requestRateLimiter
+	passwordResetLimiterOnce  sync.Once
+)
+
+// passwordResetLimiters lazily builds the email/IP rate limiters for RequestPasswordReset from
+// the server.passwordReset.* config, once config has been loaded.
+func passwordResetLimiters() (email *requestRateLimiter, ip *requestRateLimiter) {
+	passwordResetLimiterOnce.Do(func() {
+		window := config.ServerPasswordResetRateLimitWindow.GetDuration()
+		if window == 0 {
+			window = time.Hour
+		}
+		passwordResetEmailLimiter = newRequestRateLimiter(config.ServerPasswordResetRateLimitPerEmail.GetInt(), window)
+		passwordResetIPLimiter = newRequestRateLimiter(config.ServerPasswordResetRateLimitPerIP.GetInt(), window)
+	})
+	return passwordResetEmailLimiter, passwordResetIPLimiter
+}
+
+// RequestPasswordResetPayload is the request body of RequestPasswordReset
+type RequestPasswordResetPayload struct {
+	Email string `json:"email"`
+}
+
+// ConfirmPasswordResetPayload is the request body of ConfirmPasswordReset
+type ConfirmPasswordResetPayload struct {
+	Token             string `json:"token"`
+	NewMasterPassword string `json:"newMasterPassword"`
+}
+
+// RequestPasswordReset generates and emails a one-time password reset token for an account
+func RequestPasswordReset(s storage.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var payload RequestPasswordResetPayload
+
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&payload); err != nil {
+			RespondWithError(w, http.StatusBadRequest, InvalidRequestPayload)
+			return
+		}
+		defer r.Body.Close()
+
+		emailLimiter, ipLimiter := passwordResetLimiters()
+		email := strings.ToLower(strings.TrimSpace(payload.Email))
+		if !ipLimiter.Allow(clientIP(r)) || !emailLimiter.Allow(email) {
+			RespondWithError(w, http.StatusTooManyRequests, "Too many requests, please try again later")
+			return
+		}
+
+		schema := r.Context().Value("schema").(string)
+		if err := app.RequestPasswordReset(s, payload.Email, schema); err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		response := model.Response{
+			Code:    http.StatusOK,
+			Status:  Success,
+			Message: "If that email is registered, a password reset link has been sent!",
+		}
+		RespondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+// ConfirmPasswordReset validates a password reset token and resets the account's login password.
+// It does not re-encrypt the account's stored secrets (Login/Email/CreditCard) — see the scope
+// note on app.ConfirmPasswordReset.
+func ConfirmPasswordReset(s storage.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var payload ConfirmPasswordResetPayload
+
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&payload); err != nil {
+			RespondWithError(w, http.StatusBadRequest, InvalidRequestPayload)
+			return
+		}
+		defer r.Body.Close()
+
+		schema := r.Context().Value("schema").(string)
+		if err := app.ConfirmPasswordReset(s, payload.Token, payload.NewMasterPassword, schema); err != nil {
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		result := model.PasswordResetResult{
+			Message:            "Login password reset successfully! This does not re-encrypt your stored secrets; contact an administrator if you believe the server passphrase itself was exposed.",
+			SecretsReencrypted: false,
+		}
+		RespondWithJSON(w, http.StatusOK, result)
+	}
+}
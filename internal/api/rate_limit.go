@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// requestRateLimiter enforces at most limit calls per key within window, tracked in-memory.
+// A limit <= 0 disables the limiter (always allows).
+type requestRateLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	hits   map[string][]time.Time
+}
+
+func newRequestRateLimiter(limit int, window time.Duration) *requestRateLimiter {
+	return &requestRateLimiter{limit: limit, window: window, hits: map[string][]time.Time{}}
+}
+
+// Allow reports whether key is still within the configured limit and, only if it is, records a
+// hit for key. A refused call records nothing.
+func (l *requestRateLimiter) Allow(key string) bool {
+	if l.limit <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-l.window)
+
+	fresh := make([]time.Time, 0, len(l.hits[key]))
+	for _, t := range l.hits[key] {
+		if t.After(cutoff) {
+			fresh = append(fresh, t)
+		}
+	}
+
+	if len(fresh) >= l.limit {
+		l.hits[key] = fresh
+		return false
+	}
+
+	l.hits[key] = append(fresh, now)
+	return true
+}
+
+// clientIP returns the client's address without the ephemeral source port, so rate limiters
+// key on the client's IP rather than r.RemoteAddr ("ip:port"), which changes on every new TCP
+// connection and would otherwise let the limiter be bypassed by reconnecting.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		want       string
+	}{
+		{"ipv4 with port", "203.0.113.10:54321", "203.0.113.10"},
+		{"ipv6 with port", "[2001:db8::1]:54321", "2001:db8::1"},
+		{"no port", "203.0.113.10", "203.0.113.10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{RemoteAddr: tt.remoteAddr}
+			if got := clientIP(r); got != tt.want {
+				t.Errorf("clientIP(%q) = %q, want %q", tt.remoteAddr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestRateLimiterAllow(t *testing.T) {
+	l := newRequestRateLimiter(2, time.Minute)
+
+	if !l.Allow("a") {
+		t.Fatal("first hit should be allowed")
+	}
+	if !l.Allow("a") {
+		t.Fatal("second hit should be allowed")
+	}
+	if l.Allow("a") {
+		t.Fatal("third hit within window should be refused")
+	}
+
+	if !l.Allow("b") {
+		t.Fatal("a different key should have its own limit")
+	}
+}
+
+func TestRequestRateLimiterWindowExpiry(t *testing.T) {
+	l := newRequestRateLimiter(1, time.Minute)
+
+	if !l.Allow("a") {
+		t.Fatal("first hit should be allowed")
+	}
+
+	l.mu.Lock()
+	l.hits["a"][0] = time.Now().Add(-2 * time.Minute)
+	l.mu.Unlock()
+
+	if !l.Allow("a") {
+		t.Fatal("hit outside the window should have been forgotten, allowing a new one")
+	}
+}
+
+func TestRequestRateLimiterDisabled(t *testing.T) {
+	l := newRequestRateLimiter(0, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		if !l.Allow("a") {
+			t.Fatal("a limiter with limit <= 0 should always allow")
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/passwall/passwall-server/internal/app"
+	"github.com/passwall/passwall-server/internal/config"
+	"github.com/passwall/passwall-server/internal/storage"
+	"github.com/passwall/passwall-server/model"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// LoginPayload is the request body of Login
+type LoginPayload struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Login authenticates a user and issues an access token. Accounts that have not confirmed
+// their email are refused a token while server.requireEmailVerification is enabled.
+func Login(s storage.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var payload LoginPayload
+
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&payload); err != nil {
+			RespondWithError(w, http.StatusBadRequest, InvalidRequestPayload)
+			return
+		}
+		defer r.Body.Close()
+
+		schema := r.Context().Value("schema").(string)
+		user, err := s.Users().FindByEmail(payload.Email, schema)
+		if err != nil {
+			RespondWithError(w, http.StatusUnauthorized, "Invalid email or password")
+			return
+		}
+
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.Password)); err != nil {
+			RespondWithError(w, http.StatusUnauthorized, "Invalid email or password")
+			return
+		}
+
+		// Refuse to mint a token for an unverified account (see app.CreateUser /
+		// app.VerifyEmail). This is the gate the email verification flow exists to enforce.
+		if err := app.RequireVerifiedEmail(user); err != nil {
+			RespondWithError(w, http.StatusForbidden, err.Error())
+			return
+		}
+
+		accessToken, err := generateAccessToken(user)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		RespondWithJSON(w, http.StatusOK, model.TokenResponse{AccessToken: accessToken})
+	}
+}
+
+func generateAccessToken(user *model.User) (string, error) {
+	expireDuration, err := time.ParseDuration(config.ServerAccessTokenExpireDuration.GetString())
+	if err != nil {
+		expireDuration = 30 * time.Minute
+	}
+
+	claims := jwt.MapClaims{
+		"sub": user.ID,
+		"exp": time.Now().Add(expireDuration).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(config.ServerSecret.GetString()))
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"github.com/passwall/passwall-server/internal/storage"
+	"github.com/passwall/passwall-server/model"
+)
+
+// BulkCreateLogins creates every login in dtos inside a single transaction for schema. Each
+// item runs in its own SAVEPOINT (see storage.Tx.SubTx): a failed item is rolled back to its
+// savepoint and recorded in Failed without aborting the parent transaction or any other item,
+// so a batch with one bad row can still commit everything else. Only a transaction-level error
+// (BeginTx/Commit failing) rolls back the whole batch.
+func BulkCreateLogins(s storage.Store, dtos []model.LoginDTO, schema string) (*model.BulkCreateLoginsResult, error) {
+	tx, err := s.BeginTx(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.BulkCreateLoginsResult{}
+
+	for _, dto := range dtos {
+		dto := dto
+
+		createdID, err := bulkCreateLoginItem(tx, &dto, schema)
+		if err != nil {
+			result.Failed = append(result.Failed, model.BulkItemError{ID: dto.ID, Error: err.Error()})
+			continue
+		}
+
+		result.Created = append(result.Created, createdID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func bulkCreateLoginItem(tx storage.Tx, dto *model.LoginDTO, schema string) (uint, error) {
+	sub, err := tx.SubTx()
+	if err != nil {
+		return 0, err
+	}
+
+	rawModel := model.ToLogin(dto)
+	encModel := EncryptModel(rawModel).(*model.Login)
+
+	createdLogin, err := sub.Logins().Create(encModel, schema)
+	if err != nil {
+		sub.Rollback()
+		return 0, err
+	}
+
+	if err := sub.Commit(); err != nil {
+		return 0, err
+	}
+
+	return createdLogin.ID, nil
+}
+
+// BulkUpdateLogins updates every login in dtos inside a single transaction for schema, isolating
+// each item in its own SAVEPOINT the same way BulkCreateLogins does.
+func BulkUpdateLogins(s storage.Store, dtos []model.LoginDTO, schema string) (*model.BulkUpdateLoginsResult, error) {
+	tx, err := s.BeginTx(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.BulkUpdateLoginsResult{}
+
+	for _, dto := range dtos {
+		dto := dto
+
+		if err := bulkUpdateLoginItem(s, tx, &dto, schema); err != nil {
+			result.Failed = append(result.Failed, model.BulkItemError{ID: dto.ID, Error: err.Error()})
+			continue
+		}
+
+		result.Updated = append(result.Updated, dto.ID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func bulkUpdateLoginItem(s storage.Store, tx storage.Tx, dto *model.LoginDTO, schema string) error {
+	sub, err := tx.SubTx()
+	if err != nil {
+		return err
+	}
+
+	login, err := sub.Logins().FindByID(dto.ID, schema)
+	if err != nil {
+		sub.Rollback()
+		return err
+	}
+
+	if _, err := UpdateLogin(s, login, dto, schema, sub); err != nil {
+		sub.Rollback()
+		return err
+	}
+
+	return sub.Commit()
+}
+
+// BulkDeleteLogins deletes every login in ids inside a single transaction for schema, isolating
+// each item in its own SAVEPOINT the same way BulkCreateLogins does.
+func BulkDeleteLogins(s storage.Store, ids []uint, schema string) (*model.BulkDeleteLoginsResult, error) {
+	tx, err := s.BeginTx(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.BulkDeleteLoginsResult{}
+
+	for _, id := range ids {
+		if err := bulkDeleteLoginItem(tx, id, schema); err != nil {
+			result.Failed = append(result.Failed, model.BulkItemError{ID: id, Error: err.Error()})
+			continue
+		}
+		result.Deleted = append(result.Deleted, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func bulkDeleteLoginItem(tx storage.Tx, id uint, schema string) error {
+	sub, err := tx.SubTx()
+	if err != nil {
+		return err
+	}
+
+	if err := sub.Logins().Delete(id, schema); err != nil {
+		sub.Rollback()
+		return err
+	}
+
+	return sub.Commit()
+}
@@ -0,0 +1,289 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/passwall/passwall-server/internal/storage"
+	"github.com/passwall/passwall-server/model"
+)
+
+// fakeLoginRepository is an in-memory storage.LoginRepository for exercising bulk operations
+// without a real database. failID marks a single login ID that every mutating call refuses,
+// simulating the one-bad-row-in-a-batch case the savepoint isolation in bulk_login.go exists for.
+type fakeLoginRepository struct {
+	logins map[uint]*model.Login
+	nextID uint
+	failID uint
+}
+
+func newFakeLoginRepository(seed []model.Login, failID uint) *fakeLoginRepository {
+	r := &fakeLoginRepository{logins: map[uint]*model.Login{}, failID: failID}
+	for _, l := range seed {
+		l := l
+		r.logins[l.ID] = &l
+		if l.ID >= r.nextID {
+			r.nextID = l.ID + 1
+		}
+	}
+	return r
+}
+
+func (r *fakeLoginRepository) All(schema string) ([]model.Login, error) {
+	logins := make([]model.Login, 0, len(r.logins))
+	for _, l := range r.logins {
+		logins = append(logins, *l)
+	}
+	return logins, nil
+}
+
+func (r *fakeLoginRepository) FindByID(id uint, schema string) (*model.Login, error) {
+	login, ok := r.logins[id]
+	if !ok {
+		return nil, errors.New("login not found")
+	}
+	found := *login
+	return &found, nil
+}
+
+func (r *fakeLoginRepository) Create(login *model.Login, schema string) (*model.Login, error) {
+	if login.ID == r.failID {
+		return nil, errors.New("simulated failure")
+	}
+	created := *login
+	created.ID = r.nextID
+	r.nextID++
+	r.logins[created.ID] = &created
+	return &created, nil
+}
+
+func (r *fakeLoginRepository) Update(login *model.Login, schema string) (*model.Login, error) {
+	if login.ID == r.failID {
+		return nil, errors.New("simulated failure")
+	}
+	if _, ok := r.logins[login.ID]; !ok {
+		return nil, errors.New("login not found")
+	}
+	updated := *login
+	r.logins[login.ID] = &updated
+	return &updated, nil
+}
+
+func (r *fakeLoginRepository) Delete(id uint, schema string) error {
+	if id == r.failID {
+		return errors.New("simulated failure")
+	}
+	if _, ok := r.logins[id]; !ok {
+		return errors.New("login not found")
+	}
+	delete(r.logins, id)
+	return nil
+}
+
+// The remaining fakes below exist only so fakeTx and fakeStore satisfy storage.Tx/storage.Store;
+// bulk login operations never call them, so they all fail loudly if that ever changes.
+type notImplementedRepository struct{}
+
+func (notImplementedRepository) notImplemented() error { return errors.New("not implemented in this fake") }
+
+type fakeEmailRepository struct{ notImplementedRepository }
+
+func (r fakeEmailRepository) All(schema string) ([]model.Email, error)             { return nil, r.notImplemented() }
+func (r fakeEmailRepository) FindByID(id uint, schema string) (*model.Email, error) { return nil, r.notImplemented() }
+func (r fakeEmailRepository) Create(e *model.Email, schema string) (*model.Email, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeEmailRepository) Update(e *model.Email, schema string) (*model.Email, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeEmailRepository) Delete(id uint, schema string) error { return r.notImplemented() }
+
+type fakeCreditCardRepository struct{ notImplementedRepository }
+
+func (r fakeCreditCardRepository) All(schema string) ([]model.CreditCard, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeCreditCardRepository) FindByID(id uint, schema string) (*model.CreditCard, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeCreditCardRepository) Create(c *model.CreditCard, schema string) (*model.CreditCard, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeCreditCardRepository) Update(c *model.CreditCard, schema string) (*model.CreditCard, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeCreditCardRepository) Delete(id uint, schema string) error { return r.notImplemented() }
+
+type fakeUserRepository struct{ notImplementedRepository }
+
+func (r fakeUserRepository) All(schema string) ([]model.User, error) { return nil, r.notImplemented() }
+func (r fakeUserRepository) FindByID(id uint, schema string) (*model.User, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeUserRepository) FindByEmail(email, schema string) (*model.User, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeUserRepository) Create(u *model.User, schema string) (*model.User, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeUserRepository) Update(u *model.User, schema string) (*model.User, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeUserRepository) Delete(id uint, schema string) error { return r.notImplemented() }
+func (r fakeUserRepository) AllSchemas() ([]string, error)       { return nil, r.notImplemented() }
+
+type fakePasswordResetRepository struct{ notImplementedRepository }
+
+func (r fakePasswordResetRepository) Create(p *model.PasswordReset, schema string) (*model.PasswordReset, error) {
+	return nil, r.notImplemented()
+}
+func (r fakePasswordResetRepository) FindByTokenHash(tokenHash, schema string) (*model.PasswordReset, error) {
+	return nil, r.notImplemented()
+}
+func (r fakePasswordResetRepository) Update(p *model.PasswordReset, schema string) (*model.PasswordReset, error) {
+	return nil, r.notImplemented()
+}
+func (r fakePasswordResetRepository) DeleteExpired(schema string) error { return r.notImplemented() }
+
+type fakeEmailVerificationRepository struct{ notImplementedRepository }
+
+func (r fakeEmailVerificationRepository) Create(v *model.EmailVerification, schema string) (*model.EmailVerification, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeEmailVerificationRepository) FindByTokenHash(tokenHash, schema string) (*model.EmailVerification, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeEmailVerificationRepository) FindByUserID(userID uint, schema string) (*model.EmailVerification, error) {
+	return nil, r.notImplemented()
+}
+func (r fakeEmailVerificationRepository) Delete(id uint, schema string) error { return r.notImplemented() }
+
+// fakeTx is a storage.Tx backed by the same fakeLoginRepository as its parent fakeStore, so a
+// sub-transaction's Commit is immediately visible to the rest of the batch, mirroring a real
+// SAVEPOINT release within its parent transaction.
+type fakeTx struct {
+	logins     *fakeLoginRepository
+	committed  bool
+	rolledBack bool
+}
+
+func newFakeTx(logins *fakeLoginRepository) *fakeTx {
+	return &fakeTx{logins: logins}
+}
+
+func (tx *fakeTx) Logins() storage.LoginRepository           { return tx.logins }
+func (tx *fakeTx) Emails() storage.EmailRepository           { return fakeEmailRepository{} }
+func (tx *fakeTx) CreditCards() storage.CreditCardRepository { return fakeCreditCardRepository{} }
+
+func (tx *fakeTx) Commit() error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.rolledBack = true
+	return nil
+}
+
+func (tx *fakeTx) SubTx() (storage.Tx, error) {
+	return newFakeTx(tx.logins), nil
+}
+
+// fakeStore backs BulkCreateLogins/BulkUpdateLogins/BulkDeleteLogins: BeginTx always hands out
+// the same fakeTx so test assertions can inspect it afterwards.
+type fakeStore struct {
+	tx *fakeTx
+}
+
+func (s *fakeStore) Users() storage.UserRepository                       { return fakeUserRepository{} }
+func (s *fakeStore) Logins() storage.LoginRepository                     { return s.tx.logins }
+func (s *fakeStore) Emails() storage.EmailRepository                     { return fakeEmailRepository{} }
+func (s *fakeStore) CreditCards() storage.CreditCardRepository           { return fakeCreditCardRepository{} }
+func (s *fakeStore) PasswordResets() storage.PasswordResetRepository     { return fakePasswordResetRepository{} }
+func (s *fakeStore) EmailVerifications() storage.EmailVerificationRepository {
+	return fakeEmailVerificationRepository{}
+}
+func (s *fakeStore) BeginTx(schema string) (storage.Tx, error) { return s.tx, nil }
+
+func TestBulkCreateLoginsIsolatesOneFailure(t *testing.T) {
+	const failingID = 2
+
+	tx := newFakeTx(newFakeLoginRepository(nil, failingID))
+	store := &fakeStore{tx: tx}
+
+	dtos := []model.LoginDTO{
+		{ID: 1, Title: "ok-1"},
+		{ID: failingID, Title: "bad"},
+		{ID: 3, Title: "ok-2"},
+	}
+
+	result, err := BulkCreateLogins(store, dtos, "public")
+	if err != nil {
+		t.Fatalf("BulkCreateLogins returned an error for the whole batch: %v", err)
+	}
+
+	if len(result.Created) != 2 {
+		t.Fatalf("expected 2 logins created, got %d (%+v)", len(result.Created), result.Created)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].ID != failingID {
+		t.Fatalf("expected exactly login %d to be reported failed, got %+v", failingID, result.Failed)
+	}
+	if !tx.committed {
+		t.Fatal("the parent transaction should still commit despite one item failing")
+	}
+	if tx.rolledBack {
+		t.Fatal("the parent transaction should not be rolled back over a single item's failure")
+	}
+}
+
+func TestBulkDeleteLoginsIsolatesOneFailure(t *testing.T) {
+	const failingID = 2
+
+	seed := []model.Login{{ID: 1}, {ID: failingID}, {ID: 3}}
+	tx := newFakeTx(newFakeLoginRepository(seed, failingID))
+	store := &fakeStore{tx: tx}
+
+	result, err := BulkDeleteLogins(store, []uint{1, failingID, 3}, "public")
+	if err != nil {
+		t.Fatalf("BulkDeleteLogins returned an error for the whole batch: %v", err)
+	}
+
+	if len(result.Deleted) != 2 {
+		t.Fatalf("expected 2 logins deleted, got %d (%+v)", len(result.Deleted), result.Deleted)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].ID != failingID {
+		t.Fatalf("expected exactly login %d to be reported failed, got %+v", failingID, result.Failed)
+	}
+	if !tx.committed {
+		t.Fatal("the parent transaction should still commit despite one item failing")
+	}
+}
+
+func TestBulkUpdateLoginsIsolatesOneFailure(t *testing.T) {
+	const failingID = 2
+
+	seed := []model.Login{{ID: 1}, {ID: failingID}, {ID: 3}}
+	tx := newFakeTx(newFakeLoginRepository(seed, failingID))
+	store := &fakeStore{tx: tx}
+
+	dtos := []model.LoginDTO{
+		{ID: 1, Title: "updated-1"},
+		{ID: failingID, Title: "updated-bad"},
+		{ID: 3, Title: "updated-3"},
+	}
+
+	result, err := BulkUpdateLogins(store, dtos, "public")
+	if err != nil {
+		t.Fatalf("BulkUpdateLogins returned an error for the whole batch: %v", err)
+	}
+
+	if len(result.Updated) != 2 {
+		t.Fatalf("expected 2 logins updated, got %d (%+v)", len(result.Updated), result.Updated)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].ID != failingID {
+		t.Fatalf("expected exactly login %d to be reported failed, got %+v", failingID, result.Failed)
+	}
+	if !tx.committed {
+		t.Fatal("the parent transaction should still commit despite one item failing")
+	}
+}
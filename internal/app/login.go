@@ -0,0 +1,44 @@
+package app
+
+import (
+	"github.com/passwall/passwall-server/internal/storage"
+	"github.com/passwall/passwall-server/model"
+)
+
+// CreateLogin creates a new login and saves it to the store
+func CreateLogin(s storage.Store, dto *model.LoginDTO, schema string) (*model.Login, error) {
+	rawModel := model.ToLogin(dto)
+	encModel := EncryptModel(rawModel)
+
+	createdLogin, err := s.Logins().Create(encModel.(*model.Login), schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return createdLogin, nil
+}
+
+// UpdateLogin updates login with the fields in dto and applies the changes in the store. When
+// tx is non-nil the update runs against it instead of s, so callers can batch several updates
+// into a single transaction (see BulkUpdateLogins).
+func UpdateLogin(s storage.Store, login *model.Login, dto *model.LoginDTO, schema string, tx storage.Tx) (*model.Login, error) {
+	rawModel := model.ToLogin(dto)
+	encModel := EncryptModel(rawModel).(*model.Login)
+
+	login.Title = encModel.Title
+	login.Username = encModel.Username
+	login.Password = encModel.Password
+	login.URL = encModel.URL
+
+	logins := s.Logins()
+	if tx != nil {
+		logins = tx.Logins()
+	}
+
+	updatedLogin, err := logins.Update(login, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedLogin, nil
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"fmt"
+	"net/smtp"
+
+	"github.com/passwall/passwall-server/internal/config"
+)
+
+// SendPasswordResetEmail sends the password reset link containing token to the given address
+// using the server's configured SMTP settings (EmailConfiguration).
+func SendPasswordResetEmail(toEmail, token string) error {
+	link := fmt.Sprintf("%s/reset-password?token=%s", config.ServerDomain.GetString(), token)
+	body := fmt.Sprintf("To reset your Passwall master passphrase, open the link below:\r\n\r\n%s\r\n\r\nIf you did not request this, you can safely ignore this email.\r\n", link)
+	return sendEmail(toEmail, "Passwall password reset", body)
+}
+
+// SendVerificationEmail sends the email confirmation link containing token to the given address
+// using the server's configured SMTP settings (EmailConfiguration).
+func SendVerificationEmail(toEmail, token string) error {
+	link := fmt.Sprintf("%s/verify-email?token=%s", config.ServerDomain.GetString(), token)
+	body := fmt.Sprintf("Welcome to Passwall! Confirm your email address by opening the link below:\r\n\r\n%s\r\n\r\nIf you did not create this account, you can safely ignore this email.\r\n", link)
+	return sendEmail(toEmail, "Confirm your Passwall account", body)
+}
+
+func sendEmail(toEmail, subject, body string) error {
+	host := config.EmailHost.GetString()
+	port := config.EmailPort.GetString()
+	username := config.EmailUsername.GetString()
+	password := config.EmailPassword.GetString()
+	from := config.EmailFromEmail.GetString()
+
+	message := []byte(fmt.Sprintf("Subject: %s\r\n\r\n%s", subject, body))
+
+	auth := smtp.PlainAuth("", username, password, host)
+	addr := fmt.Sprintf("%s:%s", host, port)
+
+	return smtp.SendMail(addr, auth, from, []string{toEmail}, message)
+}
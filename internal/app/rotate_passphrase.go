@@ -0,0 +1,124 @@
+package app
+
+import (
+	"github.com/passwall/passwall-server/internal/config"
+	"github.com/passwall/passwall-server/internal/storage"
+	"github.com/passwall/passwall-server/model"
+	"github.com/spf13/viper"
+)
+
+// RotatePassphrase re-encrypts every Login, Email and CreditCard row in schema: it decrypts
+// each row with oldPassphrase and re-encrypts it with newPassphrase. Rows are decrypted first
+// and only written back once every row in schema has decrypted successfully, so a single bad
+// row fails the whole schema before any row is persisted with the new passphrase. All writes
+// for schema run inside a single storage.Tx: if any write fails partway through, the whole
+// transaction is rolled back so the schema is never left with a mix of old- and new-passphrase
+// ciphertext.
+func RotatePassphrase(s storage.Store, oldPassphrase, newPassphrase, schema string) error {
+	previousPassphrase := config.ServerPassphrase.GetString()
+	defer viper.Set(config.ServerPassphrase.String(), previousPassphrase)
+
+	viper.Set(config.ServerPassphrase.String(), oldPassphrase)
+
+	logins, err := s.Logins().All(schema)
+	if err != nil {
+		return err
+	}
+	decLogins, err := decryptLogins(logins)
+	if err != nil {
+		return err
+	}
+
+	emails, err := s.Emails().All(schema)
+	if err != nil {
+		return err
+	}
+	decEmails, err := decryptEmails(emails)
+	if err != nil {
+		return err
+	}
+
+	creditCards, err := s.CreditCards().All(schema)
+	if err != nil {
+		return err
+	}
+	decCreditCards, err := decryptCreditCards(creditCards)
+	if err != nil {
+		return err
+	}
+
+	viper.Set(config.ServerPassphrase.String(), newPassphrase)
+
+	tx, err := s.BeginTx(schema)
+	if err != nil {
+		return err
+	}
+
+	if err := rotateWrite(tx, schema, decLogins, decEmails, decCreditCards); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func rotateWrite(tx storage.Tx, schema string, logins []model.Login, emails []model.Email, creditCards []model.CreditCard) error {
+	for i := range logins {
+		encLogin := EncryptModel(&logins[i]).(*model.Login)
+		if _, err := tx.Logins().Update(encLogin, schema); err != nil {
+			return err
+		}
+	}
+
+	for i := range emails {
+		encEmail := EncryptModel(&emails[i]).(*model.Email)
+		if _, err := tx.Emails().Update(encEmail, schema); err != nil {
+			return err
+		}
+	}
+
+	for i := range creditCards {
+		encCreditCard := EncryptModel(&creditCards[i]).(*model.CreditCard)
+		if _, err := tx.CreditCards().Update(encCreditCard, schema); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decryptLogins(logins []model.Login) ([]model.Login, error) {
+	decrypted := make([]model.Login, len(logins))
+	for i := range logins {
+		decModel, err := DecryptModel(&logins[i])
+		if err != nil {
+			return nil, err
+		}
+		decrypted[i] = *decModel.(*model.Login)
+	}
+	return decrypted, nil
+}
+
+func decryptEmails(emails []model.Email) ([]model.Email, error) {
+	decrypted := make([]model.Email, len(emails))
+	for i := range emails {
+		decModel, err := DecryptModel(&emails[i])
+		if err != nil {
+			return nil, err
+		}
+		decrypted[i] = *decModel.(*model.Email)
+	}
+	return decrypted, nil
+}
+
+func decryptCreditCards(creditCards []model.CreditCard) ([]model.CreditCard, error) {
+	decrypted := make([]model.CreditCard, len(creditCards))
+	for i := range creditCards {
+		decModel, err := DecryptModel(&creditCards[i])
+		if err != nil {
+			return nil, err
+		}
+		decrypted[i] = *decModel.(*model.CreditCard)
+	}
+	return decrypted, nil
+}
@@ -0,0 +1,39 @@
+package app
+
+import "testing"
+
+func TestGenerateSecureTokenIsUniqueAndNonEmpty(t *testing.T) {
+	a, err := generateSecureToken()
+	if err != nil {
+		t.Fatalf("generateSecureToken: %v", err)
+	}
+	b, err := generateSecureToken()
+	if err != nil {
+		t.Fatalf("generateSecureToken: %v", err)
+	}
+
+	if a == b {
+		t.Fatal("two generated tokens should not collide")
+	}
+	if len(a) == 0 {
+		t.Fatal("generated token should not be empty")
+	}
+}
+
+func TestHashTokenIsDeterministicAndHidesInput(t *testing.T) {
+	token := "some-raw-token"
+
+	h1 := hashToken(token)
+	h2 := hashToken(token)
+	if h1 != h2 {
+		t.Fatal("hashToken should be deterministic for the same input")
+	}
+
+	if h1 == token {
+		t.Fatal("hashToken should not return the raw token")
+	}
+
+	if other := hashToken("a-different-token"); other == h1 {
+		t.Fatal("different tokens should hash differently")
+	}
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/passwall/passwall-server/internal/config"
+	"github.com/passwall/passwall-server/model"
+	"github.com/spf13/viper"
+)
+
+func resetEmailVerificationConfig() {
+	viper.Set(config.ServerRequireEmailVerification.String(), nil)
+	viper.Set(config.ServerEnv.String(), nil)
+}
+
+func TestRequireVerifiedEmailExplicitlyEnabled(t *testing.T) {
+	defer resetEmailVerificationConfig()
+	viper.Set(config.ServerRequireEmailVerification.String(), true)
+
+	if err := RequireVerifiedEmail(&model.User{Verified: false}); err != ErrEmailNotVerified {
+		t.Fatalf("expected ErrEmailNotVerified for an unverified user, got %v", err)
+	}
+	if err := RequireVerifiedEmail(&model.User{Verified: true}); err != nil {
+		t.Fatalf("expected no error for a verified user, got %v", err)
+	}
+}
+
+func TestRequireVerifiedEmailExplicitlyDisabled(t *testing.T) {
+	defer resetEmailVerificationConfig()
+	viper.Set(config.ServerRequireEmailVerification.String(), false)
+
+	if err := RequireVerifiedEmail(&model.User{Verified: false}); err != nil {
+		t.Fatalf("expected no error when verification is explicitly disabled, got %v", err)
+	}
+}
+
+func TestRequireEmailVerificationEnabledFallsBackToServerEnv(t *testing.T) {
+	defer resetEmailVerificationConfig()
+
+	viper.Set(config.ServerEnv.String(), "dev")
+	if requireEmailVerificationEnabled() {
+		t.Fatal("expected verification to default to disabled in dev when unconfigured")
+	}
+
+	viper.Set(config.ServerEnv.String(), "prod")
+	if !requireEmailVerificationEnabled() {
+		t.Fatal("expected verification to default to enabled outside dev when unconfigured")
+	}
+}
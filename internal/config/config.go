@@ -35,6 +35,16 @@ type ServerConfiguration struct {
 	AccessTokenExpireDuration  string `default:"30m"`
 	RefreshTokenExpireDuration string `default:"15d"`
 	APIKey                     string `default:"my-secret-api-key"`
+	RequireEmailVerification   bool   `default:"true"`
+	PasswordReset              PasswordResetConfiguration
+}
+
+// PasswordResetConfiguration is the required parameters for the password reset flow
+type PasswordResetConfiguration struct {
+	TokenTTL          string `default:"30m"`
+	RateLimitPerEmail int    `default:"3"`  // max requests per email per RateLimitWindow
+	RateLimitPerIP    int    `default:"10"` // max requests per IP per RateLimitWindow
+	RateLimitWindow   string `default:"1h"`
 }
 
 // DatabaseConfiguration is the required parameters to set up a DB instance
@@ -114,73 +124,90 @@ func initializeConfig(configPath, configName string) {
 }
 
 func bindEnvs() {
-	viper.BindEnv("server.env", "PW_ENV")
-	viper.BindEnv("server.port", "PORT")
-	viper.BindEnv("server.domain", "DOMAIN")
-	viper.BindEnv("server.passphrase", "PW_SERVER_PASSPHRASE")
-	viper.BindEnv("server.secret", "PW_SERVER_SECRET")
-	viper.BindEnv("server.timeout", "PW_SERVER_TIMEOUT")
-
-	viper.BindEnv("server.generatedPasswordLength", "PW_SERVER_GENERATED_PASSWORD_LENGTH")
-	viper.BindEnv("server.accessTokenExpireDuration", "PW_SERVER_ACCESS_TOKEN_EXPIRE_DURATION")
-	viper.BindEnv("server.refreshTokenExpireDuration", "PW_SERVER_REFRESH_TOKEN_EXPIRE_DURATION")
-
-	viper.BindEnv("server.apiKey", "PW_SERVER_API_KEY")
-	viper.BindEnv("server.recaptcha", "PW_SERVER_RECAPTCHA")
-
-	viper.BindEnv("database.name", "PW_DB_NAME")
-	viper.BindEnv("database.username", "PW_DB_USERNAME")
-	viper.BindEnv("database.password", "PW_DB_PASSWORD")
-	viper.BindEnv("database.host", "PW_DB_HOST")
-	viper.BindEnv("database.port", "PW_DB_PORT")
-	viper.BindEnv("database.logmode", "PW_DB_LOG_MODE")
+	viper.BindEnv(ServerEnv.String(), "PW_ENV")
+	viper.BindEnv(ServerPort.String(), "PORT")
+	viper.BindEnv(ServerDomain.String(), "DOMAIN")
+	viper.BindEnv(ServerPassphrase.String(), "PW_SERVER_PASSPHRASE")
+	viper.BindEnv(ServerSecret.String(), "PW_SERVER_SECRET")
+	viper.BindEnv(ServerTimeout.String(), "PW_SERVER_TIMEOUT")
+
+	viper.BindEnv(ServerGeneratedPasswordLength.String(), "PW_SERVER_GENERATED_PASSWORD_LENGTH")
+	viper.BindEnv(ServerAccessTokenExpireDuration.String(), "PW_SERVER_ACCESS_TOKEN_EXPIRE_DURATION")
+	viper.BindEnv(ServerRefreshTokenExpireDuration.String(), "PW_SERVER_REFRESH_TOKEN_EXPIRE_DURATION")
+
+	viper.BindEnv(ServerAPIKey.String(), "PW_SERVER_API_KEY")
+	viper.BindEnv(ServerRecaptcha.String(), "PW_SERVER_RECAPTCHA")
+	viper.BindEnv(ServerRequireEmailVerification.String(), "PW_SERVER_REQUIRE_EMAIL_VERIFICATION")
+
+	viper.BindEnv(ServerPasswordResetTokenTTL.String(), "PW_SERVER_PASSWORD_RESET_TOKEN_TTL")
+	viper.BindEnv(ServerPasswordResetRateLimitPerEmail.String(), "PW_SERVER_PASSWORD_RESET_RATE_LIMIT_PER_EMAIL")
+	viper.BindEnv(ServerPasswordResetRateLimitPerIP.String(), "PW_SERVER_PASSWORD_RESET_RATE_LIMIT_PER_IP")
+	viper.BindEnv(ServerPasswordResetRateLimitWindow.String(), "PW_SERVER_PASSWORD_RESET_RATE_LIMIT_WINDOW")
+
+	viper.BindEnv(DatabaseName.String(), "PW_DB_NAME")
+	viper.BindEnv(DatabaseUsername.String(), "PW_DB_USERNAME")
+	viper.BindEnv(DatabasePassword.String(), "PW_DB_PASSWORD")
+	viper.BindEnv(DatabaseHost.String(), "PW_DB_HOST")
+	viper.BindEnv(DatabasePort.String(), "PW_DB_PORT")
+	viper.BindEnv(DatabaseLogMode.String(), "PW_DB_LOG_MODE")
 
 	// "require", "verify-full", "verify-ca", "disable" supported for postgres
-	viper.BindEnv("database.sslmode", "PW_DB_SSL_MODE")
-
-	viper.BindEnv("email.host", "PW_EMAIL_HOST")
-	viper.BindEnv("email.port", "PW_EMAIL_PORT")
-	viper.BindEnv("email.username", "PW_EMAIL_USERNAME")
-	viper.BindEnv("email.password", "PW_EMAIL_PASSWORD")
-	viper.BindEnv("email.fromEmail", "PW_EMAIL_FROM_EMAIL")
-	viper.BindEnv("email.fromName", "PW_EMAIL_FROM_NAME")
-	viper.BindEnv("email.apiKey", "PW_EMAIL_API_KEY")
+	viper.BindEnv(DatabaseSSLMode.String(), "PW_DB_SSL_MODE")
+
+	viper.BindEnv(EmailHost.String(), "PW_EMAIL_HOST")
+	viper.BindEnv(EmailPort.String(), "PW_EMAIL_PORT")
+	viper.BindEnv(EmailUsername.String(), "PW_EMAIL_USERNAME")
+	viper.BindEnv(EmailPassword.String(), "PW_EMAIL_PASSWORD")
+	viper.BindEnv(EmailFromEmail.String(), "PW_EMAIL_FROM_EMAIL")
+	viper.BindEnv(EmailFromName.String(), "PW_EMAIL_FROM_NAME")
+	viper.BindEnv(EmailAPIKey.String(), "PW_EMAIL_API_KEY")
 }
 
 func setDefaults() {
 
 	// Server defaults
-	viper.SetDefault("server.env", "prod")
-	viper.SetDefault("server.port", "3625")
-	viper.SetDefault("server.domain", "https://vault.passwall.io")
-	viper.SetDefault("server.passphrase", generateKey())
-	viper.SetDefault("server.secret", generateKey())
-	viper.SetDefault("server.timeout", 24)
-	viper.SetDefault("server.generatedPasswordLength", 16)
-	viper.SetDefault("server.accessTokenExpireDuration", "30m")
-	viper.SetDefault("server.refreshTokenExpireDuration", "15d")
-	viper.SetDefault("server.apiKey", generateKey())
-	viper.SetDefault("server.recaptcha", "GoogleRecaptchaSecret")
+	viper.SetDefault(ServerEnv.String(), "prod")
+	viper.SetDefault(ServerPort.String(), "3625")
+	viper.SetDefault(ServerDomain.String(), "https://vault.passwall.io")
+	viper.SetDefault(ServerPassphrase.String(), generateKey())
+	viper.SetDefault(ServerSecret.String(), generateKey())
+	viper.SetDefault(ServerTimeout.String(), 24)
+	viper.SetDefault(ServerGeneratedPasswordLength.String(), 16)
+	viper.SetDefault(ServerAccessTokenExpireDuration.String(), "30m")
+	viper.SetDefault(ServerRefreshTokenExpireDuration.String(), "15d")
+	viper.SetDefault(ServerAPIKey.String(), generateKey())
+	viper.SetDefault(ServerRecaptcha.String(), "GoogleRecaptchaSecret")
+
+	// ServerRequireEmailVerification has no default set here: setDefaults runs before the config
+	// file is read, so a default computed from ServerEnv at this point would miss a server.env
+	// set in the config file and see only PW_ENV or the "prod" default set at the top of this
+	// function. Its effective default is instead resolved lazily by
+	// app.requireEmailVerificationEnabled.
+
+	viper.SetDefault(ServerPasswordResetTokenTTL.String(), "30m")
+	viper.SetDefault(ServerPasswordResetRateLimitPerEmail.String(), 3)
+	viper.SetDefault(ServerPasswordResetRateLimitPerIP.String(), 10)
+	viper.SetDefault(ServerPasswordResetRateLimitWindow.String(), "1h")
 
 	// Database defaults
-	viper.SetDefault("database.name", "passwall")
-	viper.SetDefault("database.username", "postgres")
-	viper.SetDefault("database.password", "password")
-	viper.SetDefault("database.host", "localhost")
-	viper.SetDefault("database.port", "5432")
-	viper.SetDefault("database.logmode", false)
+	viper.SetDefault(DatabaseName.String(), "passwall")
+	viper.SetDefault(DatabaseUsername.String(), "postgres")
+	viper.SetDefault(DatabasePassword.String(), "password")
+	viper.SetDefault(DatabaseHost.String(), "localhost")
+	viper.SetDefault(DatabasePort.String(), "5432")
+	viper.SetDefault(DatabaseLogMode.String(), false)
 
 	// "require", "verify-full", "verify-ca", "disable" supported for postgres
-	viper.SetDefault("database.sslmode", "disable")
+	viper.SetDefault(DatabaseSSLMode.String(), "disable")
 
 	// Email defaults
-	viper.SetDefault("email.host", "smtp.passwall.io")
-	viper.SetDefault("email.port", "25")
-	viper.SetDefault("email.username", "hello@passwall.io")
-	viper.SetDefault("email.password", "password")
-	viper.SetDefault("email.fromName", "Passwall")
-	viper.SetDefault("email.fromEmail", "hello@passwall.io")
-	viper.SetDefault("email.apiKey", "apiKey")
+	viper.SetDefault(EmailHost.String(), "smtp.passwall.io")
+	viper.SetDefault(EmailPort.String(), "25")
+	viper.SetDefault(EmailUsername.String(), "hello@passwall.io")
+	viper.SetDefault(EmailPassword.String(), "password")
+	viper.SetDefault(EmailFromName.String(), "Passwall")
+	viper.SetDefault(EmailFromEmail.String(), "hello@passwall.io")
+	viper.SetDefault(EmailAPIKey.String(), "apiKey")
 }
 
 func generateKey() string {
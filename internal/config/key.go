@@ -0,0 +1,113 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Key is a typed configuration key backed by a dotted viper path. Using Key instead of a bare
+// string lets the compiler catch typos like "server.evn" and gives a single place (All) that
+// lists every setting the server honors.
+type Key string
+
+// Typed configuration keys, one per setting bound in bindEnvs/setDefaults
+const (
+	ServerEnv                        Key = "server.env"
+	ServerPort                       Key = "server.port"
+	ServerDomain                     Key = "server.domain"
+	ServerPassphrase                 Key = "server.passphrase"
+	ServerSecret                     Key = "server.secret"
+	ServerTimeout                    Key = "server.timeout"
+	ServerGeneratedPasswordLength    Key = "server.generatedPasswordLength"
+	ServerAccessTokenExpireDuration  Key = "server.accessTokenExpireDuration"
+	ServerRefreshTokenExpireDuration Key = "server.refreshTokenExpireDuration"
+	ServerAPIKey                     Key = "server.apiKey"
+	ServerRecaptcha                  Key = "server.recaptcha"
+	ServerRequireEmailVerification   Key = "server.requireEmailVerification"
+
+	ServerPasswordResetTokenTTL          Key = "server.passwordReset.tokenTTL"
+	ServerPasswordResetRateLimitPerEmail Key = "server.passwordReset.rateLimitPerEmail"
+	ServerPasswordResetRateLimitPerIP    Key = "server.passwordReset.rateLimitPerIP"
+	ServerPasswordResetRateLimitWindow   Key = "server.passwordReset.rateLimitWindow"
+
+	DatabaseName     Key = "database.name"
+	DatabaseUsername Key = "database.username"
+	DatabasePassword Key = "database.password"
+	DatabaseHost     Key = "database.host"
+	DatabasePort     Key = "database.port"
+	DatabaseLogMode  Key = "database.logmode"
+	DatabaseSSLMode  Key = "database.sslmode"
+
+	EmailHost      Key = "email.host"
+	EmailPort      Key = "email.port"
+	EmailUsername  Key = "email.username"
+	EmailPassword  Key = "email.password"
+	EmailFromEmail Key = "email.fromEmail"
+	EmailFromName  Key = "email.fromName"
+	EmailAPIKey    Key = "email.apiKey"
+)
+
+// allKeys is every configuration key the server honors, in declaration order
+var allKeys = []Key{
+	ServerEnv, ServerPort, ServerDomain, ServerPassphrase, ServerSecret,
+	ServerTimeout, ServerGeneratedPasswordLength, ServerAccessTokenExpireDuration,
+	ServerRefreshTokenExpireDuration, ServerAPIKey, ServerRecaptcha, ServerRequireEmailVerification,
+	ServerPasswordResetTokenTTL, ServerPasswordResetRateLimitPerEmail,
+	ServerPasswordResetRateLimitPerIP, ServerPasswordResetRateLimitWindow,
+	DatabaseName, DatabaseUsername, DatabasePassword, DatabaseHost, DatabasePort,
+	DatabaseLogMode, DatabaseSSLMode,
+	EmailHost, EmailPort, EmailUsername, EmailPassword, EmailFromEmail, EmailFromName, EmailAPIKey,
+}
+
+// All returns every configuration key the server honors, used by `passwall config dump`
+func All() []Key {
+	return allKeys
+}
+
+// sensitiveKeys are never printed in cleartext by `passwall config dump`
+var sensitiveKeys = map[Key]bool{
+	ServerPassphrase: true,
+	ServerSecret:     true,
+	ServerAPIKey:     true,
+	DatabasePassword: true,
+	EmailPassword:    true,
+	EmailAPIKey:      true,
+}
+
+// Sensitive reports whether k holds a secret that should be redacted rather than printed, e.g.
+// by `passwall config dump`.
+func (k Key) Sensitive() bool {
+	return sensitiveKeys[k]
+}
+
+// String returns the dotted viper path this Key represents
+func (k Key) String() string {
+	return string(k)
+}
+
+// GetString returns the key's value as a string
+func (k Key) GetString() string {
+	return viper.GetString(k.String())
+}
+
+// GetInt returns the key's value as an int
+func (k Key) GetInt() int {
+	return viper.GetInt(k.String())
+}
+
+// GetBool returns the key's value as a bool
+func (k Key) GetBool() bool {
+	return viper.GetBool(k.String())
+}
+
+// GetDuration returns the key's value as a time.Duration, parsing it if stored as a string
+func (k Key) GetDuration() time.Duration {
+	return viper.GetDuration(k.String())
+}
+
+// IsSet reports whether the key has been explicitly set by a config file, environment variable,
+// or viper.SetDefault, as opposed to falling back to a zero value.
+func (k Key) IsSet() bool {
+	return viper.IsSet(k.String())
+}
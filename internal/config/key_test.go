@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestKeySensitive(t *testing.T) {
+	sensitive := []Key{ServerPassphrase, ServerSecret, ServerAPIKey, DatabasePassword, EmailPassword, EmailAPIKey}
+	for _, k := range sensitive {
+		if !k.Sensitive() {
+			t.Errorf("expected %s to be sensitive", k)
+		}
+	}
+
+	notSensitive := []Key{ServerEnv, ServerPort, ServerDomain, DatabaseHost}
+	for _, k := range notSensitive {
+		if k.Sensitive() {
+			t.Errorf("expected %s not to be sensitive", k)
+		}
+	}
+}
+
+func TestKeyGettersReflectViperValue(t *testing.T) {
+	defer viper.Set(ServerPort.String(), nil)
+	defer viper.Set(ServerTimeout.String(), nil)
+	defer viper.Set(ServerRequireEmailVerification.String(), nil)
+	defer viper.Set(ServerAccessTokenExpireDuration.String(), nil)
+
+	viper.Set(ServerPort.String(), "4000")
+	if got := ServerPort.GetString(); got != "4000" {
+		t.Errorf("GetString() = %q, want %q", got, "4000")
+	}
+
+	viper.Set(ServerTimeout.String(), 48)
+	if got := ServerTimeout.GetInt(); got != 48 {
+		t.Errorf("GetInt() = %d, want 48", got)
+	}
+
+	viper.Set(ServerRequireEmailVerification.String(), true)
+	if got := ServerRequireEmailVerification.GetBool(); got != true {
+		t.Errorf("GetBool() = %v, want true", got)
+	}
+
+	viper.Set(ServerAccessTokenExpireDuration.String(), "1h")
+	if got := ServerAccessTokenExpireDuration.GetDuration(); got != time.Hour {
+		t.Errorf("GetDuration() = %v, want %v", got, time.Hour)
+	}
+}
+
+func TestKeyIsSet(t *testing.T) {
+	defer viper.Set(ServerDomain.String(), nil)
+
+	if ServerDomain.IsSet() {
+		t.Fatal("expected ServerDomain to be unset before it is ever configured")
+	}
+
+	viper.Set(ServerDomain.String(), "https://example.com")
+	if !ServerDomain.IsSet() {
+		t.Fatal("expected ServerDomain to be set after viper.Set")
+	}
+}
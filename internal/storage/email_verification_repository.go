@@ -0,0 +1,12 @@
+package storage
+
+import "github.com/passwall/passwall-server/model"
+
+// EmailVerificationRepository is the interface for email verification token operations. It is
+// embedded in Store alongside the other per-model repositories.
+type EmailVerificationRepository interface {
+	Create(verification *model.EmailVerification, schema string) (*model.EmailVerification, error)
+	FindByTokenHash(tokenHash string, schema string) (*model.EmailVerification, error)
+	FindByUserID(userID uint, schema string) (*model.EmailVerification, error)
+	Delete(id uint, schema string) error
+}
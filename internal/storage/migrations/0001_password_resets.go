@@ -0,0 +1,9 @@
+package migrations
+
+import "github.com/passwall/passwall-server/model"
+
+// PasswordResetModels lists the models that back the password_resets table so callers can
+// pass them to gorm's AutoMigrate alongside the existing Login/Email/CreditCard models.
+var PasswordResetModels = []interface{}{
+	&model.PasswordReset{},
+}
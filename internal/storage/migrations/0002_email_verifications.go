@@ -0,0 +1,9 @@
+package migrations
+
+import "github.com/passwall/passwall-server/model"
+
+// EmailVerificationModels lists the models that back the email_verifications table so callers
+// can pass them to gorm's AutoMigrate alongside the existing Login/Email/CreditCard models.
+var EmailVerificationModels = []interface{}{
+	&model.EmailVerification{},
+}
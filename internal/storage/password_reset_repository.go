@@ -0,0 +1,12 @@
+package storage
+
+import "github.com/passwall/passwall-server/model"
+
+// PasswordResetRepository is the interface for password reset token operations.
+// It is embedded in Store alongside the other per-model repositories.
+type PasswordResetRepository interface {
+	Create(passwordReset *model.PasswordReset, schema string) (*model.PasswordReset, error)
+	FindByTokenHash(tokenHash string, schema string) (*model.PasswordReset, error)
+	Update(passwordReset *model.PasswordReset, schema string) (*model.PasswordReset, error)
+	DeleteExpired(schema string) error
+}
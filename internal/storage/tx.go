@@ -0,0 +1,18 @@
+package storage
+
+// Tx is a transaction scoped to a single tenant schema, returned by Store.BeginTx. Exactly one
+// of Commit or Rollback must be called. It exposes the same per-model repositories as Store so
+// app-layer code (e.g. UpdateLogin) can thread it through unchanged whether or not it is part
+// of a larger bulk operation.
+type Tx interface {
+	Logins() LoginRepository
+	Emails() EmailRepository
+	CreditCards() CreditCardRepository
+	Commit() error
+	Rollback() error
+
+	// SubTx starts a nested transaction (a SQL SAVEPOINT) scoped to a single item of a larger
+	// batch. Its Commit releases the savepoint; its Rollback rolls back to it without aborting
+	// the parent Tx, so one failed item does not poison every other item in the batch.
+	SubTx() (Tx, error)
+}
@@ -0,0 +1,18 @@
+package storage
+
+import "github.com/passwall/passwall-server/model"
+
+// UserRepository is the interface for user operations. It is embedded in Store alongside the
+// other per-model repositories.
+type UserRepository interface {
+	All(schema string) ([]model.User, error)
+	FindByID(id uint, schema string) (*model.User, error)
+	FindByEmail(email string, schema string) (*model.User, error)
+	Create(user *model.User, schema string) (*model.User, error)
+	Update(user *model.User, schema string) (*model.User, error)
+	Delete(id uint, schema string) error
+
+	// AllSchemas lists every tenant schema that has a users table, so operations like
+	// `passwall admin rotate-passphrase` can iterate every tenant without direct DB access.
+	AllSchemas() ([]string, error)
+}
@@ -0,0 +1,25 @@
+package model
+
+// BulkItemError reports why a single item in a bulk request failed
+type BulkItemError struct {
+	ID    uint   `json:"id"`
+	Error string `json:"error"`
+}
+
+// BulkCreateLoginsResult is the response of a bulk login creation request
+type BulkCreateLoginsResult struct {
+	Created []uint          `json:"created"`
+	Failed  []BulkItemError `json:"failed"`
+}
+
+// BulkUpdateLoginsResult is the response of a bulk login update request
+type BulkUpdateLoginsResult struct {
+	Updated []uint          `json:"updated"`
+	Failed  []BulkItemError `json:"failed"`
+}
+
+// BulkDeleteLoginsResult is the response of a bulk login deletion request
+type BulkDeleteLoginsResult struct {
+	Deleted []uint          `json:"deleted"`
+	Failed  []BulkItemError `json:"failed"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// EmailVerification is a single-use token proving ownership of the email address a user
+// registered with. Only the SHA-256 hash of the token is persisted, never the token itself.
+type EmailVerification struct {
+	ID        uint      `json:"id" gorm:"primary_key"`
+	UserID    uint      `json:"user_id"`
+	TokenHash string    `json:"-" gorm:"unique_index;size:64"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
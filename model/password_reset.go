@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// PasswordReset represents a single-use password reset token for a user.
+// Only the SHA-256 hash of the token is persisted, never the token itself.
+type PasswordReset struct {
+	ID        uint      `json:"id" gorm:"primary_key"`
+	UserID    uint      `json:"user_id"`
+	TokenHash string    `json:"-" gorm:"unique_index;size:64"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Used      bool      `json:"used" gorm:"default:false"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PasswordResetResult is returned by a successful ConfirmPasswordReset. SecretsReencrypted is
+// always false today: see the scope note on app.ConfirmPasswordReset. It is a dedicated field
+// rather than prose in Message so API consumers can detect the limitation programmatically
+// instead of having to parse a human-readable string.
+type PasswordResetResult struct {
+	Message            string `json:"message"`
+	SecretsReencrypted bool   `json:"secretsReencrypted"`
+}
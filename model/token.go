@@ -0,0 +1,6 @@
+package model
+
+// TokenResponse is returned by a successful Login
+type TokenResponse struct {
+	AccessToken string `json:"accessToken"`
+}
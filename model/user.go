@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// User represents an account that authenticates against the API and owns the logins, emails
+// and credit cards stored in its own database schema.
+type User struct {
+	ID        uint      `json:"id" gorm:"primary_key"`
+	Email     string    `json:"email" gorm:"unique_index"`
+	Password  string    `json:"-"`
+	IsAdmin   bool      `json:"is_admin" gorm:"default:false"`
+	Verified  bool      `json:"verified" gorm:"default:false"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}